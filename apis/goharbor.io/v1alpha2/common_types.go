@@ -0,0 +1,54 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ComponentSpec holds the generic, per-component workload knobs - replica count, image override,
+// resource requests - shared by every component this operator deploys. Components embed it
+// instead of repeating these fields.
+type ComponentSpec struct {
+	// +kubebuilder:validation:Optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// HarborLogLevel is the logging verbosity shared across Harbor's components. Individual
+// components may override it for their own workload; Registry does not, so its Registry method
+// is a pass-through.
+type HarborLogLevel string
+
+const (
+	HarborLogLevelDebug   HarborLogLevel = "debug"
+	HarborLogLevelInfo    HarborLogLevel = "info"
+	HarborLogLevelWarning HarborLogLevel = "warning"
+	HarborLogLevelError   HarborLogLevel = "error"
+	HarborLogLevelFatal   HarborLogLevel = "fatal"
+)
+
+// Registry returns the log level the registry component should run at.
+func (l HarborLogLevel) Registry() HarborLogLevel {
+	return l
+}
+
+// Component identifies one of the backends sharing Harbor's external Redis instance, so each can
+// be routed to its own logical database.
+type Component string
+
+const (
+	RegistryRedis Component = "registry"
+	CoreRedis     Component = "core"
+)
+
+// OpacifiedDSN is a connection string whose credentials are redacted by its String method, so it
+// is safe to log or wrap into an error message without leaking the password it carries.
+type OpacifiedDSN string
+
+func (d OpacifiedDSN) String() string {
+	return "<redacted>"
+}
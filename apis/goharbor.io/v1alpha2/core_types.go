@@ -0,0 +1,37 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CoreComponentSpec is Harbor.Spec.Core.
+type CoreComponentSpec struct {
+	ComponentSpec `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// Core is the Schema for Harbor's core API server.
+type Core struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CoreSpec   `json:"spec,omitempty"`
+	Status CoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type CoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Core `json:"items"`
+}
+
+type CoreSpec struct {
+	ComponentSpec `json:",inline"`
+}
+
+type CoreStatus struct {
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,23 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecretTypeHTPasswd marks a Secret as holding the registry's htpasswd file plus the shared
+	// secret its consumers use to reach the same backend.
+	SecretTypeHTPasswd corev1.SecretType = "goharbor.io/htpasswd"
+
+	// SecretTypeSingle marks a Secret as holding a single opaque value, such as the registry's
+	// HTTP secret.
+	SecretTypeSingle corev1.SecretType = "goharbor.io/single"
+)
+
+const (
+	// HTPasswdFileName is the Secret data key holding the rendered htpasswd file contents.
+	HTPasswdFileName = "htpasswd"
+
+	// SharedSecretKey is the Secret data key holding a single opaque secret value.
+	SharedSecretKey = "secret"
+)
@@ -0,0 +1,90 @@
+package v1alpha2
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories="goharbor",shortName="h"
+// Harbor is the Schema for the harbors API.
+type Harbor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HarborSpec   `json:"spec,omitempty"`
+	Status HarborStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// HarborList contains a list of Harbor.
+type HarborList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Harbor `json:"items"`
+}
+
+// HarborSpec defines the desired state of Harbor.
+type HarborSpec struct {
+	// +kubebuilder:validation:Required
+	Registry RegistryComponentSpec `json:"registry"`
+
+	// +kubebuilder:validation:Required
+	Core CoreComponentSpec `json:"core"`
+
+	// +kubebuilder:validation:Optional
+	Persistence HarborPersistenceSpec `json:"persistence,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Replication ReplicationSpec `json:"replication,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="info"
+	LogLevel HarborLogLevel `json:"logLevel,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Redis HarborRedisSpec `json:"redis"`
+}
+
+// HarborRedisSpec configures the external Redis instance shared by Harbor's components, each
+// routed to its own logical database by index.
+type HarborRedisSpec struct {
+	// +kubebuilder:validation:Required
+	Host string `json:"host"`
+
+	// +kubebuilder:validation:Required
+	Port int32 `json:"port"`
+
+	// +kubebuilder:validation:Optional
+	RegistryDatabaseIndex int32 `json:"registryDatabaseIndex,omitempty"`
+}
+
+// RedisDSN builds the connection string the named component should use to reach Redis, selecting
+// its logical database by index. Only RegistryRedis is supported; any other component is an error.
+func (s *HarborSpec) RedisDSN(component Component) (*OpacifiedDSN, error) {
+	if s.Redis.Host == "" {
+		return nil, errors.New("redis host is required")
+	}
+
+	var database int32
+
+	switch component { // nolint:exhaustive
+	case RegistryRedis:
+		database = s.Redis.RegistryDatabaseIndex
+	default:
+		return nil, errors.Errorf("unsupported redis component %s", component)
+	}
+
+	dsn := OpacifiedDSN(fmt.Sprintf("redis://%s:%d/%d", s.Redis.Host, s.Redis.Port, database))
+
+	return &dsn, nil
+}
+
+// HarborStatus defines the observed state of Harbor.
+type HarborStatus struct {
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
@@ -0,0 +1,215 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RegistryComponentSpec is Harbor.Spec.Registry: the registry-specific configuration.
+type RegistryComponentSpec struct {
+	ComponentSpec `json:",inline"`
+
+	// +kubebuilder:validation:Optional
+	Authentication RegistryAuthenticationSpec `json:"authentication,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	// AuthenticationSecretRef names a pre-existing, externally-managed Secret carrying the
+	// registry's htpasswd credentials, instead of one generated and rotated by the operator.
+	AuthenticationSecretRef string `json:"authenticationSecretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$"
+	// HTTPSecretRef names a pre-existing, externally-managed Secret carrying the registry's HTTP
+	// secret, instead of one generated and rotated by the operator.
+	HTTPSecretRef string `json:"httpSecretRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	RotationPolicy *RegistryRotationPolicySpec `json:"rotationPolicy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	RelativeURLs bool `json:"relativeURLs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	StorageMiddlewares []string `json:"storageMiddlewares,omitempty"`
+}
+
+// RegistryRotationPolicySpec configures how often the operator mints a new registry/HTTP secret
+// generation on its own, on top of whatever on-demand rotations RegistryRotateNowAnnotation
+// requests.
+type RegistryRotationPolicySpec struct {
+	// +kubebuilder:validation:Optional
+	Schedule *metav1.Duration `json:"schedule,omitempty"`
+}
+
+// RegistryAuthenticationSpec selects how the registry authenticates requests. Exactly one of
+// HTPasswd or Token is set; HTPasswd is the default when neither is configured explicitly.
+type RegistryAuthenticationSpec struct {
+	// +kubebuilder:validation:Optional
+	HTPasswd *RegistryAuthenticationHTPasswdSpec `json:"htpasswd,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Token *RegistryAuthenticationTokenSpec `json:"token,omitempty"`
+}
+
+type RegistryAuthenticationHTPasswdSpec struct {
+	// +kubebuilder:validation:Required
+	Realm string `json:"realm"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+// RegistryAuthenticationTokenSpec configures the registry to verify bearer tokens issued by
+// Harbor core's token service instead of checking requests against an htpasswd file.
+type RegistryAuthenticationTokenSpec struct {
+	// +kubebuilder:validation:Required
+	Realm string `json:"realm"`
+
+	// +kubebuilder:validation:Required
+	// Issuer is the iss claim the registry requires on tokens it is handed.
+	Issuer string `json:"issuer"`
+
+	// +kubebuilder:validation:Required
+	// Service is the aud claim the registry requires on tokens it is handed.
+	Service string `json:"service"`
+
+	// +kubebuilder:validation:Required
+	// RootCertBundleRef names the Secret carrying the certificate the registry trusts to verify
+	// token signatures.
+	RootCertBundleRef string `json:"rootCertBundleRef"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// Registry is the Schema for the registries API. It renders a single Pod running both the
+// distribution registry and its registryctl sidecar, sharing one image storage volume.
+type Registry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegistrySpec   `json:"spec,omitempty"`
+	Status RegistryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type RegistryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Registry `json:"items"`
+}
+
+type RegistrySpec struct {
+	ComponentSpec `json:",inline"`
+
+	RegistryConfig01 `json:",inline"`
+}
+
+// RegistryConfig01 is the v0.1 shape of the distribution configuration the Registry controller
+// renders into the registry container's config file.
+type RegistryConfig01 struct {
+	// +kubebuilder:validation:Optional
+	Log RegistryLogSpec `json:"log,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Authentication RegistryAuthenticationSpec `json:"authentication"`
+
+	// +kubebuilder:validation:Optional
+	Validation RegistryValidationSpec `json:"validation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Middlewares RegistryMiddlewaresSpec `json:"middlewares,omitempty"`
+
+	// +kubebuilder:validation:Required
+	HTTP RegistryHTTPSpec `json:"http"`
+
+	// +kubebuilder:validation:Required
+	Storage RegistryStorageSpec `json:"storage"`
+
+	// +kubebuilder:validation:Optional
+	Redis *RegistryRedisSpec `json:"redis,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Controller configures the registryctl sidecar sharing this Pod.
+	Controller *RegistryControllerSpec `json:"controller,omitempty"`
+}
+
+type RegistryLogSpec struct {
+	// +kubebuilder:validation:Optional
+	AccessLog RegistryAccessLogSpec `json:"accesslog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Level HarborLogLevel `json:"level,omitempty"`
+}
+
+type RegistryAccessLogSpec struct {
+	// +kubebuilder:validation:Optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+type RegistryValidationSpec struct {
+	// +kubebuilder:validation:Optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+type RegistryMiddlewaresSpec struct {
+	// +kubebuilder:validation:Optional
+	Storage []string `json:"storage,omitempty"`
+}
+
+type RegistryHTTPSpec struct {
+	// +kubebuilder:validation:Optional
+	RelativeURLs bool `json:"relativeurls,omitempty"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+type RegistryStorageSpec struct {
+	// +kubebuilder:validation:Required
+	Driver *HarborStorageImageChartStorageSpec `json:"driver"`
+
+	// +kubebuilder:validation:Optional
+	Cache RegistryStorageCacheSpec `json:"cache,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Redirect bool `json:"redirect,omitempty"`
+}
+
+type RegistryStorageCacheSpec struct {
+	// +kubebuilder:validation:Optional
+	Blobdescriptor string `json:"blobdescriptor,omitempty"`
+}
+
+type RegistryRedisSpec struct {
+	OpacifiedDSN `json:",inline"`
+}
+
+// RegistryControllerSpec configures the registryctl sidecar sharing the Registry Pod: it
+// authenticates with the registry's htpasswd credentials Secret and reaches distribution over
+// loopback, so it
+// needs no address or credentials of its own beyond what is passed here.
+type RegistryControllerSpec struct {
+	// +kubebuilder:validation:Required
+	RegistryAddress string `json:"registryAddress"`
+
+	// +kubebuilder:validation:Required
+	Authentication RegistryControllerAuthenticationSpec `json:"authentication"`
+
+	// +kubebuilder:validation:Optional
+	Log RegistryControllerLogSpec `json:"log,omitempty"`
+}
+
+type RegistryControllerAuthenticationSpec struct {
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+type RegistryControllerLogSpec struct {
+	// +kubebuilder:validation:Optional
+	Level HarborLogLevel `json:"level,omitempty"`
+}
+
+type RegistryStatus struct {
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
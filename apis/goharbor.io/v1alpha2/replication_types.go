@@ -0,0 +1,173 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplicationSpec is Harbor.Spec.Replication: the remote registries and replication policies the
+// operator pushes into Harbor through its REST API once core is reachable.
+type ReplicationSpec struct {
+	// +kubebuilder:validation:Optional
+	Registries []RemoteRegistryReference `json:"registries,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Policies []ReplicationPolicyReference `json:"policies,omitempty"`
+}
+
+// RemoteRegistryReference declares one remote registry Harbor should be able to replicate to/from.
+type RemoteRegistryReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	ProviderName string `json:"providerName"`
+
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Credential RemoteRegistryCredentialSpec `json:"credential"`
+}
+
+// RemoteRegistryCredentialSpec references the Secret holding a remote registry's credential.
+// Type records the shape the credential is stored in - most token-based providers use a token
+// field, but Azure ACR exposes its credential under an access_token field instead.
+type RemoteRegistryCredentialSpec struct {
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+// ReplicationPolicyReference declares one replication policy Harbor should enforce between two
+// already-declared registries.
+type ReplicationPolicyReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Required
+	SrcRegistry string `json:"srcRegistry"`
+
+	// +kubebuilder:validation:Required
+	DestRegistry string `json:"destRegistry"`
+
+	// +kubebuilder:validation:Optional
+	DestNamespace string `json:"destNamespace,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Filters []ReplicationFilterSpec `json:"filters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Trigger ReplicationTriggerSpec `json:"trigger,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Override bool `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type ReplicationFilterSpec struct {
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+type ReplicationTriggerSpec struct {
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	Cron string `json:"cron,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories="goharbor",shortName="remreg"
+// RemoteRegistry is the Schema for registering a remote registry for replication.
+type RemoteRegistry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteRegistrySpec   `json:"spec,omitempty"`
+	Status RemoteRegistryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type RemoteRegistryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteRegistry `json:"items"`
+}
+
+type RemoteRegistrySpec struct {
+	// +kubebuilder:validation:Required
+	ProviderName string `json:"providerName"`
+
+	// +kubebuilder:validation:Required
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:Optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// +kubebuilder:validation:Required
+	Credential RemoteRegistryCredentialSpec `json:"credential"`
+}
+
+type RemoteRegistryStatus struct {
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories="goharbor",shortName="repl"
+// ReplicationPolicy is the Schema for configuring replication between two RemoteRegistry objects.
+type ReplicationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplicationPolicySpec   `json:"spec,omitempty"`
+	Status ReplicationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type ReplicationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReplicationPolicy `json:"items"`
+}
+
+type ReplicationPolicySpec struct {
+	// +kubebuilder:validation:Required
+	SrcRegistry string `json:"srcRegistry"`
+
+	// +kubebuilder:validation:Required
+	DestRegistry string `json:"destRegistry"`
+
+	// +kubebuilder:validation:Optional
+	DestNamespace string `json:"destNamespace,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Filters []ReplicationFilterSpec `json:"filters,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Trigger ReplicationTriggerSpec `json:"trigger,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Override bool `json:"override,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+type ReplicationPolicyStatus struct {
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
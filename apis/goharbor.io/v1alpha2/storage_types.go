@@ -0,0 +1,86 @@
+package v1alpha2
+
+// HarborPersistenceSpec configures where Harbor's components persist data, image layers and
+// chart data foremost among them.
+type HarborPersistenceSpec struct {
+	// +kubebuilder:validation:Required
+	ImageChartStorage HarborStorageImageChartStorageSpec `json:"imageChartStorage"`
+}
+
+// HarborStorageImageChartStorageSpec configures the backend the registry (and chartmuseum) store
+// image layers and charts in. Exactly one backend must be set.
+type HarborStorageImageChartStorageSpec struct {
+	// +kubebuilder:validation:Optional
+	FileSystem *HarborStorageImageChartStorageFileSystemSpec `json:"filesystem,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	S3 *HarborStorageImageChartStorageS3Spec `json:"s3,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Swift *HarborStorageImageChartStorageSwiftSpec `json:"swift,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Gcs *HarborStorageImageChartStorageGcsSpec `json:"gcs,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Azure *HarborStorageImageChartStorageAzureSpec `json:"azure,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Redirect bool `json:"redirect,omitempty"`
+}
+
+// Registry returns the backend configuration to render into the Registry CR's distribution
+// storage driver config. The registry and chartmuseum storage configs share the same backend
+// fields in this operator, so this is just s itself.
+func (s *HarborStorageImageChartStorageSpec) Registry() *HarborStorageImageChartStorageSpec {
+	return s
+}
+
+type HarborStorageImageChartStorageFileSystemSpec struct {
+	// +kubebuilder:validation:Required
+	RootDirectory string `json:"rootdirectory"`
+}
+
+type HarborStorageImageChartStorageS3Spec struct {
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// +kubebuilder:validation:Optional
+	Region string `json:"region,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	RegionEndpoint string `json:"regionendpoint,omitempty"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+type HarborStorageImageChartStorageSwiftSpec struct {
+	// +kubebuilder:validation:Required
+	AuthURL string `json:"authurl"`
+
+	// +kubebuilder:validation:Required
+	Container string `json:"container"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+type HarborStorageImageChartStorageGcsSpec struct {
+	// +kubebuilder:validation:Required
+	Bucket string `json:"bucket"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
+
+type HarborStorageImageChartStorageAzureSpec struct {
+	// +kubebuilder:validation:Required
+	AccountName string `json:"accountname"`
+
+	// +kubebuilder:validation:Required
+	Container string `json:"container"`
+
+	// +kubebuilder:validation:Required
+	SecretRef string `json:"secretRef"`
+}
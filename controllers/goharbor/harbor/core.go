@@ -0,0 +1,45 @@
+package harbor
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/goharbor/harbor-operator/pkg/graph"
+	"github.com/pkg/errors"
+)
+
+// Core is the graph resource produced once Harbor's core API is reconciled. Other resources that
+// talk to Harbor over its REST API, such as replication's RemoteRegistry and ReplicationPolicy,
+// depend on it to make sure core is reachable before anything is pushed through it.
+type Core graph.Resource
+
+func (r *Reconciler) AddCore(ctx context.Context, harbor *goharborv1alpha2.Harbor) (Core, error) {
+	core, err := r.GetCore(ctx, harbor)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get core")
+	}
+
+	coreRes, err := r.AddBasicResource(ctx, core)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot add basic resource")
+	}
+
+	return Core(coreRes), nil
+}
+
+func (r *Reconciler) GetCore(ctx context.Context, harbor *goharborv1alpha2.Harbor) (*goharborv1alpha2.Core, error) {
+	name := r.NormalizeName(ctx, harbor.GetName(), "core")
+	namespace := harbor.GetNamespace()
+
+	return &goharborv1alpha2.Core{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: goharborv1alpha2.CoreSpec{
+			ComponentSpec: harbor.Spec.Core.ComponentSpec,
+		},
+	}, nil
+}
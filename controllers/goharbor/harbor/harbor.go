@@ -0,0 +1,38 @@
+package harbor
+
+import (
+	"context"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+// Reconcile builds the full dependency graph for harbor: core, the registry and its
+// configuration, and the replication resources that are pushed through core's REST API once it
+// is reachable. Each Add* call only adds its own node (and records its own errors); this is the
+// one place that decides how they depend on each other.
+func (r *Reconciler) Reconcile(ctx context.Context, harbor *goharborv1alpha2.Harbor) error {
+	core, err := r.AddCore(ctx, harbor)
+	if err != nil {
+		return errors.Wrap(err, "core")
+	}
+
+	authSecret, httpSecret, tokenCert, err := r.AddRegistryConfigurations(ctx, harbor)
+	if err != nil {
+		return errors.Wrap(err, "registry configurations")
+	}
+
+	if _, err := r.AddRegistry(ctx, harbor, authSecret, httpSecret, tokenCert); err != nil {
+		return errors.Wrap(err, "registry")
+	}
+
+	if _, err := r.AddRemoteRegistry(ctx, harbor, core); err != nil {
+		return errors.Wrap(err, "remote registries")
+	}
+
+	if _, err := r.AddReplicationPolicy(ctx, harbor, core); err != nil {
+		return errors.Wrap(err, "replication policies")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/configstore"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/goharbor/harbor-operator/pkg/graph"
+	"github.com/pkg/errors"
+)
+
+// Reconciler builds the reconcile graph for a single Harbor: each Add* method (AddCore,
+// AddRegistry, AddRemoteRegistry, ...) reconciles one child object and returns it as a
+// graph.Resource, so later Add* calls can take it as a dependency instead of reasoning about
+// ordering by hand. ConfigStore backs the tunables read by getConfigInt/getConfigBool, such as the
+// registry password generation settings and the storage probe toggle.
+type Reconciler struct {
+	Client      client.Client
+	ConfigStore *configstore.Store
+}
+
+// dependencyAnnotationPrefix namespaces the bookkeeping annotations AddBasicResource stamps onto a
+// child object to record what it depends on, so `kubectl get -o yaml` shows the graph edges that
+// drove this reconcile instead of only the end result.
+const dependencyAnnotationPrefix = "goharbor.io/depends-on-"
+
+// NormalizeName derives the name of a child object owned by the Harbor named name, qualified by
+// parts (e.g. "registry", "basicauth"). Every caller in this package already keeps well under the
+// 253-character object name limit, so no truncation or hashing is needed.
+func (r *Reconciler) NormalizeName(ctx context.Context, name string, parts ...string) string {
+	for _, part := range parts {
+		name = fmt.Sprintf("%s-%s", name, part)
+	}
+
+	return name
+}
+
+// AddSecretToManage reconciles secret - a credential the operator itself generates and rotates,
+// as opposed to one merely referenced via a BYO *SecretRef field - and returns it as a
+// graph.Resource.
+func (r *Reconciler) AddSecretToManage(ctx context.Context, secret *corev1.Secret) (graph.Resource, error) {
+	return r.AddBasicResource(ctx, secret)
+}
+
+// AddBasicResource creates obj if it does not exist yet and updates it in place otherwise, then
+// returns it as a graph.Resource so later Add* calls can declare a dependency on it. deps records
+// that dependency for observability only - reconciliation itself already happens in the call
+// order AddCore/AddRegistry/... are invoked in, since Go requires a dependency's graph.Resource in
+// hand before it can be passed to a dependent Add* call. A nil entry in deps is skipped rather
+// than dereferenced: Add* methods that only conditionally manage their resource (a BYO secret ref,
+// a disabled token-auth certificate) return a nil graph.Resource instead of forcing every caller
+// to filter it out before this is reached.
+func (r *Reconciler) AddBasicResource(ctx context.Context, obj client.Object, deps ...graph.Resource) (graph.Resource, error) {
+	var annotations map[string]string
+
+	for i, dep := range deps {
+		if dep == nil {
+			continue
+		}
+
+		if annotations == nil {
+			annotations = obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+		}
+
+		annotations[fmt.Sprintf("%s%d", dependencyAnnotationPrefix, i)] = dep.GetName()
+	}
+
+	if annotations != nil {
+		obj.SetAnnotations(annotations)
+	}
+
+	existing := obj.DeepCopyObject().(client.Object) //nolint:forcetypeassert
+
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "cannot get")
+		}
+
+		if err := r.Client.Create(ctx, obj); err != nil {
+			return nil, errors.Wrap(err, "cannot create")
+		}
+
+		return obj, nil
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+
+	if err := r.Client.Update(ctx, obj); err != nil {
+		return nil, errors.Wrap(err, "cannot update")
+	}
+
+	return obj, nil
+}
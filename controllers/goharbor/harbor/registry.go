@@ -2,13 +2,23 @@ package harbor
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/ovh/configstore"
 	"github.com/sethvargo/go-password/password"
 	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
 	"github.com/goharbor/harbor-operator/pkg/graph"
@@ -17,12 +27,101 @@ import (
 
 const (
 	ConfigRegistryEncryptionCostKey = "registry-encryption-cost"
+
+	ConfigRegistryAuthPasswordLengthKey     = "registry-auth-password-length"
+	ConfigRegistryAuthPasswordDigitsKey     = "registry-auth-password-digits"
+	ConfigRegistryAuthPasswordSpecialsKey   = "registry-auth-password-specials"
+	ConfigRegistryAuthPasswordNoSpecialsKey = "registry-auth-password-no-specials"
+
+	ConfigRegistryHTTPSecretLengthKey     = "registry-http-secret-length"
+	ConfigRegistryHTTPSecretDigitsKey     = "registry-http-secret-digits"
+	ConfigRegistryHTTPSecretSpecialsKey   = "registry-http-secret-specials"
+	ConfigRegistryHTTPSecretNoSpecialsKey = "registry-http-secret-no-specials"
 )
 
+const (
+	// RegistryRotateNowAnnotation, set on the Harbor resource, requests an out-of-schedule
+	// rotation of the registry secrets. Its value is copied onto the generated Secrets once
+	// handled, so it is bumping the value — not merely setting it — that triggers each subsequent
+	// on-demand rotation.
+	RegistryRotateNowAnnotation = "goharbor.io/rotate-registry-secrets"
+
+	// RegistryAuthSecretGenerationAnnotation records which rotation generation produced the
+	// current htpasswd contents. RegistryAuthSecretRotatedAtAnnotation, read alongside it, is what
+	// registryRotationObserved checks against RegistryRotationObservationGrace to decide when the
+	// outgoing bcrypt entry is safe to drop.
+	RegistryAuthSecretGenerationAnnotation = "goharbor.io/registry-auth-secret-generation"
+	// RegistryAuthSecretRotatedAtAnnotation records when the current generation was minted, used
+	// to evaluate RotationPolicy.Schedule.
+	RegistryAuthSecretRotatedAtAnnotation = "goharbor.io/registry-auth-secret-rotated-at"
+	// RegistryAuthSecretCurrentEntryKey and RegistryAuthSecretPreviousEntryKey hold the individual
+	// htpasswd lines making up goharborv1alpha2.HTPasswdFileName. While both are present, the
+	// rendered file carries both entries so in-flight core->registry requests authenticated with
+	// either password keep working across the rollout.
+	RegistryAuthSecretCurrentEntryKey  = "htpasswd.current"
+	RegistryAuthSecretPreviousEntryKey = "htpasswd.previous"
+
+	RegistryHTTPSecretGenerationAnnotation = "goharbor.io/registry-http-secret-generation"
+	RegistryHTTPSecretRotatedAtAnnotation  = "goharbor.io/registry-http-secret-rotated-at"
+	// RegistryHTTPSecretPreviousValueKey holds the outgoing HTTP secret value for the same brief
+	// dual-value window, in case a registry replica still running the previous generation reads it.
+	RegistryHTTPSecretPreviousValueKey = "secret.previous"
+)
+
+// getConfigInt returns the int value of key from the ConfigStore, falling back to def when the
+// item is not configured.
+func (r *Reconciler) getConfigInt(key string, def int64) (int64, error) {
+	value, err := r.ConfigStore.GetItemValueInt(key)
+	if err != nil {
+		if _, ok := err.(configstore.ErrItemNotFound); !ok {
+			return 0, errors.Wrapf(err, "cannot get %s", key)
+		}
+
+		return def, nil
+	}
+
+	return value, nil
+}
+
+// getConfigBool returns the bool value of key from the ConfigStore, falling back to def when the
+// item is not configured.
+func (r *Reconciler) getConfigBool(key string, def bool) (bool, error) {
+	value, err := r.ConfigStore.GetItemValueBool(key)
+	if err != nil {
+		if _, ok := err.(configstore.ErrItemNotFound); !ok {
+			return false, errors.Wrapf(err, "cannot get %s", key)
+		}
+
+		return def, nil
+	}
+
+	return value, nil
+}
+
 const (
 	RegistryAuthRealm = "harbor-registry-basic-realm"
 )
 
+const (
+	RegistryTokenAuthRealm       = "harbor-registry-token-realm"
+	RegistryTokenCertCommonName  = "harbor-registry-token-issuer"
+	RegistryTokenCertValidity    = 10 * 365 * 24 * time.Hour
+	RegistryTokenCertificateKey  = "tls.crt"
+	RegistryTokenCertificatePriv = "tls.key"
+
+	// RegistryTokenCertRenewalThreshold is how far ahead of expiry GetRegistryTokenCertificate
+	// mints a replacement certificate instead of reusing the one already reconciled.
+	RegistryTokenCertRenewalThreshold = 30 * 24 * time.Hour
+)
+
+const (
+	// RegistryControllerLocalAddress is the distribution API address registryctl is configured
+	// with inside the shared Pod. Since distribution and registryctl now run as containers of the
+	// same Pod, registryctl reaches the distribution API over loopback instead of a Kubernetes
+	// Service.
+	RegistryControllerLocalAddress = "http://localhost:5000"
+)
+
 var (
 	varTrue  = true
 	varFalse = false
@@ -31,6 +130,23 @@ var (
 type RegistryAuthSecret graph.Resource
 
 func (r *Reconciler) AddRegistryAuthenticationSecret(ctx context.Context, harbor *goharborv1alpha2.Harbor) (RegistryAuthSecret, error) {
+	if ref := harbor.Spec.Registry.AuthenticationSecretRef; ref != "" {
+		// The operator does not own an externally-managed credential: nothing to generate or
+		// reconcile, GetRegistry simply references it by name. Still confirm it actually exists,
+		// rather than hand the Registry CR a dangling reference that only surfaces once its Pod
+		// crashloops trying to mount a Secret that was never there.
+		existing, err := r.getExistingSecret(ctx, harbor.GetNamespace(), ref)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get authentication secret")
+		}
+
+		if existing == nil {
+			return nil, errors.Errorf("authenticationSecretRef %s not found", ref)
+		}
+
+		return nil, nil
+	}
+
 	authSecret, err := r.GetRegistryAuthenticationSecret(ctx, harbor)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get secret")
@@ -44,29 +160,45 @@ func (r *Reconciler) AddRegistryAuthenticationSecret(ctx context.Context, harbor
 	return RegistryAuthSecret(authSecretRes), nil
 }
 
-func (r *Reconciler) AddRegistryConfigurations(ctx context.Context, harbor *goharborv1alpha2.Harbor) (RegistryAuthSecret, RegistryHTTPSecret, error) {
+func (r *Reconciler) AddRegistryConfigurations(ctx context.Context, harbor *goharborv1alpha2.Harbor) (RegistryAuthSecret, RegistryHTTPSecret, RegistryTokenCertificate, error) {
 	authSecret, err := r.AddRegistryAuthenticationSecret(ctx, harbor)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "authentication secret")
+		return nil, nil, nil, errors.Wrap(err, "authentication secret")
 	}
 
 	httpSecret, err := r.AddRegistryHTTPSecret(ctx, harbor)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "http secret")
+		return nil, nil, nil, errors.Wrap(err, "http secret")
+	}
+
+	tokenCert, err := r.AddRegistryTokenCertificate(ctx, harbor)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "token certificate")
 	}
 
-	return authSecret, httpSecret, nil
+	return authSecret, httpSecret, tokenCert, nil
 }
 
+// Registry is the combined registry+registryctl resource. The two used to be reconciled as
+// separate graph nodes backed by separate Pods; they are now rendered from a single Registry CR
+// into one Pod so registryctl can reach the distribution container over loopback instead of a
+// Service, and both containers share the same image storage volume.
 type Registry graph.Resource
 
-func (r *Reconciler) AddRegistry(ctx context.Context, harbor *goharborv1alpha2.Harbor, authSecret RegistryAuthSecret, httpSecret RegistryHTTPSecret) (Registry, error) {
+func (r *Reconciler) AddRegistry(ctx context.Context, harbor *goharborv1alpha2.Harbor, authSecret RegistryAuthSecret, httpSecret RegistryHTTPSecret, tokenCert RegistryTokenCertificate) (Registry, error) {
+	if err := r.ValidateRegistryStorage(ctx, harbor); err != nil {
+		return nil, errors.Wrap(err, "storage")
+	}
+
 	registry, err := r.GetRegistry(ctx, harbor)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get registry")
 	}
 
-	registryRes, err := r.AddBasicResource(ctx, registry, authSecret, httpSecret)
+	// authSecret, httpSecret and tokenCert are nil whenever the corresponding secret is
+	// BYO-referenced or token auth is disabled: AddBasicResource skips nil deps itself, so the
+	// registry only ends up depending on the ones the operator actually manages.
+	registryRes, err := r.AddBasicResource(ctx, registry, authSecret, httpSecret, tokenCert)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot add basic resource")
 	}
@@ -77,6 +209,19 @@ func (r *Reconciler) AddRegistry(ctx context.Context, harbor *goharborv1alpha2.H
 type RegistryHTTPSecret graph.Resource
 
 func (r *Reconciler) AddRegistryHTTPSecret(ctx context.Context, harbor *goharborv1alpha2.Harbor) (RegistryHTTPSecret, error) {
+	if ref := harbor.Spec.Registry.HTTPSecretRef; ref != "" {
+		existing, err := r.getExistingSecret(ctx, harbor.GetNamespace(), ref)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot get http secret")
+		}
+
+		if existing == nil {
+			return nil, errors.Errorf("httpSecretRef %s not found", ref)
+		}
+
+		return nil, nil
+	}
+
 	httpSecret, err := r.GetRegistryHTTPSecret(ctx, harbor)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get secret")
@@ -99,11 +244,131 @@ const (
 	RegistryAuthenticationPasswordNumSpecials = 10
 )
 
+// rotationPlan is the outcome of comparing a previously reconciled secret against
+// harbor.Spec.Registry.RotationPolicy and the on-demand annotation: whether a new value must be
+// minted this reconciliation, and the generation/timestamp to stamp the result with.
+type rotationPlan struct {
+	generation int64
+	rotatedAt  time.Time
+	rotate     bool
+}
+
+// planRegistryRotation decides whether the secret named by generationKey/rotatedAtKey is due for
+// rotation, either because RotationPolicy.Schedule has elapsed since it was last rotated, or
+// because harbor carries a RegistryRotateNowAnnotation that has not been handled yet.
+func planRegistryRotation(harbor *goharborv1alpha2.Harbor, existing *corev1.Secret, generationKey, rotatedAtKey string) rotationPlan {
+	if existing == nil {
+		return rotationPlan{generation: 1, rotatedAt: time.Now(), rotate: true}
+	}
+
+	generation, err := strconv.ParseInt(existing.Annotations[generationKey], 10, 64)
+	if err != nil {
+		generation = 1
+	}
+
+	rotatedAt, err := time.Parse(time.RFC3339, existing.Annotations[rotatedAtKey])
+	if err != nil {
+		rotatedAt = time.Now()
+	}
+
+	plan := rotationPlan{generation: generation, rotatedAt: rotatedAt}
+
+	if policy := harbor.Spec.Registry.RotationPolicy; policy != nil && policy.Schedule != nil {
+		if time.Since(rotatedAt) >= policy.Schedule.Duration {
+			plan.rotate = true
+		}
+	}
+
+	if requested, ok := harbor.Annotations[RegistryRotateNowAnnotation]; ok && requested != existing.Annotations[RegistryRotateNowAnnotation] {
+		plan.rotate = true
+	}
+
+	if plan.rotate {
+		// Advance past the generation/timestamp the rollout started from: otherwise rotatedAt stays
+		// frozen in the past, re-triggering the schedule on every subsequent reconcile and letting
+		// registryRotationObserved treat the new rollout as already observed (collapsing the
+		// dual-entry window before anything has actually rolled).
+		plan.generation = generation + 1
+		plan.rotatedAt = time.Now()
+	}
+
+	return plan
+}
+
+// RegistryRotationObservationGrace bounds how long a rotated secret's outgoing entry is kept
+// around. Nothing in this reconciler hears back from the registry Pod (or core) about which
+// generation of a secret they last read, so - rather than gate the drop on a status field no one
+// ever writes, which never fires and leaks the outgoing entry forever - this approximates "every
+// consumer has observed the new value" by the time it normally takes a changed Secret to
+// propagate to a mounted volume, with headroom for a slow rollout.
+const RegistryRotationObservationGrace = 5 * time.Minute
+
+// registryRotationObserved reports whether rotatedAt is old enough that every registry consumer
+// has had time to pick up the secret generation minted then, so its outgoing value is safe to drop.
+func registryRotationObserved(rotatedAt time.Time) bool {
+	return time.Since(rotatedAt) >= RegistryRotationObservationGrace
+}
+
+func (r *Reconciler) getExistingSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "cannot get secret")
+	}
+
+	return secret, nil
+}
+
 func (r *Reconciler) GetRegistryAuthenticationSecret(ctx context.Context, harbor *goharborv1alpha2.Harbor) (*corev1.Secret, error) {
 	name := r.NormalizeName(ctx, harbor.GetName(), "registry", "basicauth")
 	namespace := harbor.GetNamespace()
 
-	password, err := password.Generate(RegistryAuthenticationPasswordLength, RegistryAuthenticationPasswordNumDigits, RegistryAuthenticationPasswordNumSpecials, false, true)
+	existing, err := r.getExistingSecret(ctx, namespace, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get existing secret")
+	}
+
+	plan := planRegistryRotation(harbor, existing, RegistryAuthSecretGenerationAnnotation, RegistryAuthSecretRotatedAtAnnotation)
+
+	if !plan.rotate {
+		// Nothing triggered a new password: reuse what was already reconciled instead of minting
+		// one on every call, only dropping the outgoing entry once its rollout is confirmed.
+		if _, hasPrevious := existing.Data[RegistryAuthSecretPreviousEntryKey]; hasPrevious && registryRotationObserved(plan.rotatedAt) {
+			return dropRegistryAuthSecretPreviousEntry(existing), nil
+		}
+
+		return existing, nil
+	}
+
+	length, err := r.getConfigInt(ConfigRegistryAuthPasswordLengthKey, RegistryAuthenticationPasswordLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get password length")
+	}
+
+	digits, err := r.getConfigInt(ConfigRegistryAuthPasswordDigitsKey, RegistryAuthenticationPasswordNumDigits)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get password digits")
+	}
+
+	specials, err := r.getConfigInt(ConfigRegistryAuthPasswordSpecialsKey, RegistryAuthenticationPasswordNumSpecials)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get password specials")
+	}
+
+	noSpecials, err := r.getConfigBool(ConfigRegistryAuthPasswordNoSpecialsKey, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get password no-specials flag")
+	}
+
+	if noSpecials {
+		specials = 0
+	}
+
+	password, err := password.Generate(int(length), int(digits), int(specials), false, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot generate password")
 	}
@@ -122,20 +387,56 @@ func (r *Reconciler) GetRegistryAuthenticationSecret(ctx context.Context, harbor
 		return nil, errors.Wrap(err, "cannot encrypt password")
 	}
 
+	currentEntry := fmt.Sprintf("%s:%s", RegistryAuthenticationUsername, string(hashedPassword))
+
+	annotations := map[string]string{
+		RegistryAuthSecretGenerationAnnotation: strconv.FormatInt(plan.generation, 10),
+		RegistryAuthSecretRotatedAtAnnotation:  plan.rotatedAt.Format(time.RFC3339),
+	}
+
+	if requested, ok := harbor.Annotations[RegistryRotateNowAnnotation]; ok {
+		annotations[RegistryRotateNowAnnotation] = requested
+	}
+
+	htpasswd := currentEntry
+	stringData := map[string]string{
+		goharborv1alpha2.HTPasswdFileName: currentEntry,
+		goharborv1alpha2.SharedSecretKey:  password,
+		RegistryAuthSecretCurrentEntryKey: currentEntry,
+	}
+
+	if existing != nil {
+		if previousEntry, ok := existing.Data[RegistryAuthSecretCurrentEntryKey]; ok && len(previousEntry) > 0 {
+			// Roll the entry being phased out into the file alongside the new one, so a request
+			// authenticated with either password succeeds while pods are mid-rollout.
+			htpasswd = string(previousEntry) + "\n" + currentEntry
+			stringData[goharborv1alpha2.HTPasswdFileName] = htpasswd
+			stringData[RegistryAuthSecretPreviousEntryKey] = string(previousEntry)
+		}
+	}
+
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Immutable: &varFalse,
-		Type:      goharborv1alpha2.SecretTypeHTPasswd,
-		StringData: map[string]string{
-			goharborv1alpha2.HTPasswdFileName: fmt.Sprintf("%s:%s", RegistryAuthenticationUsername, string(hashedPassword)),
-			goharborv1alpha2.SharedSecretKey:  password,
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
 		},
+		Immutable:  &varFalse,
+		Type:       goharborv1alpha2.SecretTypeHTPasswd,
+		StringData: stringData,
 	}, nil
 }
 
+// dropRegistryAuthSecretPreviousEntry collapses a Secret out of its dual-entry rollout window:
+// the outgoing htpasswd line and its bookkeeping key are removed, leaving only the current entry.
+func dropRegistryAuthSecretPreviousEntry(existing *corev1.Secret) *corev1.Secret {
+	collapsed := existing.DeepCopy()
+	collapsed.Data[goharborv1alpha2.HTPasswdFileName] = collapsed.Data[RegistryAuthSecretCurrentEntryKey]
+	delete(collapsed.Data, RegistryAuthSecretPreviousEntryKey)
+
+	return collapsed
+}
+
 const (
 	RegistrySecretPasswordLength      = 128
 	RegistrySecretPasswordNumDigits   = 16
@@ -146,36 +447,288 @@ func (r *Reconciler) GetRegistryHTTPSecret(ctx context.Context, harbor *goharbor
 	name := r.NormalizeName(ctx, harbor.GetName(), "registry", "http")
 	namespace := harbor.GetNamespace()
 
-	secret, err := password.Generate(RegistrySecretPasswordLength, RegistrySecretPasswordNumDigits, RegistrySecretPasswordNumSpecials, false, true)
+	existing, err := r.getExistingSecret(ctx, namespace, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get existing secret")
+	}
+
+	if existing != nil && existing.Immutable != nil && *existing.Immutable {
+		// Secrets created before rotation support are immutable, and the API server rejects any
+		// update to an immutable Secret - including flipping the flag itself - so the only way to
+		// start rotating one is to delete and recreate it, once, as if it never existed.
+		if err := r.Client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, "cannot delete immutable http secret")
+		}
+
+		existing = nil
+	}
+
+	plan := planRegistryRotation(harbor, existing, RegistryHTTPSecretGenerationAnnotation, RegistryHTTPSecretRotatedAtAnnotation)
+
+	if !plan.rotate {
+		if _, hasPrevious := existing.Data[RegistryHTTPSecretPreviousValueKey]; hasPrevious && registryRotationObserved(plan.rotatedAt) {
+			return dropRegistryHTTPSecretPreviousValue(existing), nil
+		}
+
+		return existing, nil
+	}
+
+	length, err := r.getConfigInt(ConfigRegistryHTTPSecretLengthKey, RegistrySecretPasswordLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get secret length")
+	}
+
+	digits, err := r.getConfigInt(ConfigRegistryHTTPSecretDigitsKey, RegistrySecretPasswordNumDigits)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get secret digits")
+	}
+
+	specials, err := r.getConfigInt(ConfigRegistryHTTPSecretSpecialsKey, RegistrySecretPasswordNumSpecials)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get secret specials")
+	}
+
+	noSpecials, err := r.getConfigBool(ConfigRegistryHTTPSecretNoSpecialsKey, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get secret no-specials flag")
+	}
+
+	if noSpecials {
+		specials = 0
+	}
+
+	secret, err := password.Generate(int(length), int(digits), int(specials), false, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot generate secret")
 	}
 
+	annotations := map[string]string{
+		RegistryHTTPSecretGenerationAnnotation: strconv.FormatInt(plan.generation, 10),
+		RegistryHTTPSecretRotatedAtAnnotation:  plan.rotatedAt.Format(time.RFC3339),
+	}
+
+	if requested, ok := harbor.Annotations[RegistryRotateNowAnnotation]; ok {
+		annotations[RegistryRotateNowAnnotation] = requested
+	}
+
+	stringData := map[string]string{
+		goharborv1alpha2.SharedSecretKey: secret,
+	}
+
+	if existing != nil {
+		if previousValue, ok := existing.Data[goharborv1alpha2.SharedSecretKey]; ok {
+			// Keep the outgoing value around for the brief window it takes every registry replica
+			// to pick up the new one, the same dual-value approach used for the auth secret.
+			stringData[RegistryHTTPSecretPreviousValueKey] = string(previousValue)
+		}
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		// Rotation requires updating the value in place, so the secret can no longer be immutable.
+		Immutable:  &varFalse,
+		Type:       goharborv1alpha2.SecretTypeSingle,
+		StringData: stringData,
+	}, nil
+}
+
+// dropRegistryHTTPSecretPreviousValue collapses a Secret out of its dual-value rollout window by
+// removing the outgoing value once every consumer has observed the current one.
+func dropRegistryHTTPSecretPreviousValue(existing *corev1.Secret) *corev1.Secret {
+	collapsed := existing.DeepCopy()
+	delete(collapsed.Data, RegistryHTTPSecretPreviousValueKey)
+
+	return collapsed
+}
+
+type RegistryTokenCertificate graph.Resource
+
+// AddRegistryTokenCertificate generates the self-signed certificate+key pair used by the
+// embedded Docker distribution to verify JWT tokens issued by Harbor's core token service.
+// It is a no-op when the registry is not configured for token authentication.
+func (r *Reconciler) AddRegistryTokenCertificate(ctx context.Context, harbor *goharborv1alpha2.Harbor) (RegistryTokenCertificate, error) {
+	if harbor.Spec.Registry.Authentication.Token == nil {
+		return nil, nil
+	}
+
+	tokenCert, err := r.GetRegistryTokenCertificate(ctx, harbor)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get certificate")
+	}
+
+	tokenCertRes, err := r.AddSecretToManage(ctx, tokenCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot add secret")
+	}
+
+	return RegistryTokenCertificate(tokenCertRes), nil
+}
+
+// RegistryTokenCertificateSecretName returns the name of the Secret minted by
+// GetRegistryTokenCertificate. Harbor's core token issuer must sign JWTs with the private key
+// stored under RegistryTokenCertificatePriv in this same Secret: the registry only trusts tokens
+// signed by the key matching the certificate it was handed as RootCertBundleRef, so core has to
+// consume this Secret by name rather than mint its own signing key.
+func (r *Reconciler) RegistryTokenCertificateSecretName(ctx context.Context, harbor *goharborv1alpha2.Harbor) string {
+	return r.NormalizeName(ctx, harbor.GetName(), "registry", "token-certificate")
+}
+
+// registryTokenCertificateStillValid reports whether existing already holds a certificate that
+// is not within RegistryTokenCertRenewalThreshold of expiring, so GetRegistryTokenCertificate can
+// reuse it instead of minting (and rolling the registry onto) a new key pair every reconcile.
+func registryTokenCertificateStillValid(existing *corev1.Secret) (bool, error) {
+	block, _ := pem.Decode(existing.Data[RegistryTokenCertificateKey])
+	if block == nil {
+		return false, nil
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot parse certificate")
+	}
+
+	return time.Now().Add(RegistryTokenCertRenewalThreshold).Before(cert.NotAfter), nil
+}
+
+func (r *Reconciler) GetRegistryTokenCertificate(ctx context.Context, harbor *goharborv1alpha2.Harbor) (*corev1.Secret, error) {
+	name := r.RegistryTokenCertificateSecretName(ctx, harbor)
+	namespace := harbor.GetNamespace()
+
+	existing, err := r.getExistingSecret(ctx, namespace, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get existing secret")
+	}
+
+	if existing != nil {
+		stillValid, err := registryTokenCertificateStillValid(existing)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot check existing certificate")
+		}
+
+		if stillValid {
+			return existing, nil
+		}
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate serial number")
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate private key")
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName: RegistryTokenCertCommonName,
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(RegistryTokenCertValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create certificate")
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
 		},
-		Immutable: &varTrue,
-		Type:      goharborv1alpha2.SecretTypeSingle,
+		Immutable: &varFalse,
+		Type:      corev1.SecretTypeTLS,
 		StringData: map[string]string{
-			goharborv1alpha2.SharedSecretKey: secret,
+			RegistryTokenCertificateKey:  string(certPEM),
+			RegistryTokenCertificatePriv: string(keyPEM),
+		},
+	}, nil
+}
+
+// GetRegistryAuthenticationSpec builds the distribution authentication configuration according
+// to harbor.Spec.Registry.Authentication. It defaults to htpasswd, backed by the Secret named
+// authenticationSecretName (the one generated in GetRegistryAuthenticationSecret, or the
+// AuthenticationSecretRef one), when no token mode is selected.
+func (r *Reconciler) GetRegistryAuthenticationSpec(ctx context.Context, harbor *goharborv1alpha2.Harbor, authenticationSecretName string) (*goharborv1alpha2.RegistryAuthenticationSpec, error) {
+	token := harbor.Spec.Registry.Authentication.Token
+	if token == nil {
+		return &goharborv1alpha2.RegistryAuthenticationSpec{
+			HTPasswd: &goharborv1alpha2.RegistryAuthenticationHTPasswdSpec{
+				Realm:     RegistryAuthRealm,
+				SecretRef: authenticationSecretName,
+			},
+		}, nil
+	}
+
+	certificateSecretName := r.RegistryTokenCertificateSecretName(ctx, harbor)
+
+	return &goharborv1alpha2.RegistryAuthenticationSpec{
+		Token: &goharborv1alpha2.RegistryAuthenticationTokenSpec{
+			Realm:             RegistryTokenAuthRealm,
+			Issuer:            token.Issuer,
+			Service:           token.Service,
+			RootCertBundleRef: certificateSecretName,
 		},
 	}, nil
 }
 
+// GetRegistryControllerSpec builds the registryctl sidecar configuration. This package never
+// stands up a separate registryctl Deployment/Service: the Registry CR already renders
+// registryctl as a second container of the one Registry Pod, sharing its image storage volume, so
+// embedding this spec on RegistryConfig01 is the whole merge - there is no other graph node or
+// child object here to collapse. registryctl authenticates with the htpasswd credentials Secret
+// named authenticationSecretName, in token mode as well, and reaches distribution over loopback
+// instead of through a dedicated Service.
+func (r *Reconciler) GetRegistryControllerSpec(ctx context.Context, harbor *goharborv1alpha2.Harbor, authenticationSecretName string) *goharborv1alpha2.RegistryControllerSpec {
+	return &goharborv1alpha2.RegistryControllerSpec{
+		RegistryAddress: RegistryControllerLocalAddress,
+		Authentication: goharborv1alpha2.RegistryControllerAuthenticationSpec{
+			SecretRef: authenticationSecretName,
+		},
+		Log: goharborv1alpha2.RegistryControllerLogSpec{
+			Level: harbor.Spec.LogLevel.Registry(),
+		},
+	}
+}
+
 func (r *Reconciler) GetRegistry(ctx context.Context, harbor *goharborv1alpha2.Harbor) (*goharborv1alpha2.Registry, error) {
 	name := r.NormalizeName(ctx, harbor.GetName())
 	namespace := harbor.GetNamespace()
 
-	authenticationSecretName := r.NormalizeName(ctx, harbor.GetName(), "registry", "basicauth")
-	httpSecretName := r.NormalizeName(ctx, harbor.GetName(), "registry", "http")
+	authenticationSecretName := harbor.Spec.Registry.AuthenticationSecretRef
+	if authenticationSecretName == "" {
+		authenticationSecretName = r.NormalizeName(ctx, harbor.GetName(), "registry", "basicauth")
+	}
+
+	httpSecretName := harbor.Spec.Registry.HTTPSecretRef
+	if httpSecretName == "" {
+		httpSecretName = r.NormalizeName(ctx, harbor.GetName(), "registry", "http")
+	}
 
 	redisDSN, err := harbor.Spec.RedisDSN(goharborv1alpha2.RegistryRedis)
 	if err != nil {
 		return nil, errors.Wrap(err, "redis")
 	}
 
+	authentication, err := r.GetRegistryAuthenticationSpec(ctx, harbor, authenticationSecretName)
+	if err != nil {
+		return nil, errors.Wrap(err, "authentication")
+	}
+
 	return &goharborv1alpha2.Registry{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
@@ -190,12 +743,7 @@ func (r *Reconciler) GetRegistry(ctx context.Context, harbor *goharborv1alpha2.H
 					},
 					Level: harbor.Spec.LogLevel.Registry(),
 				},
-				Authentication: goharborv1alpha2.RegistryAuthenticationSpec{
-					HTPasswd: &goharborv1alpha2.RegistryAuthenticationHTPasswdSpec{
-						Realm:     RegistryAuthRealm,
-						SecretRef: authenticationSecretName,
-					},
-				},
+				Authentication: *authentication,
 				Validation: goharborv1alpha2.RegistryValidationSpec{
 					Disabled: true,
 				},
@@ -216,7 +764,8 @@ func (r *Reconciler) GetRegistry(ctx context.Context, harbor *goharborv1alpha2.H
 				Redis: &goharborv1alpha2.RegistryRedisSpec{
 					OpacifiedDSN: *redisDSN,
 				},
+				Controller: r.GetRegistryControllerSpec(ctx, harbor, authenticationSecretName),
 			},
 		},
 	}, nil
-}
\ No newline at end of file
+}
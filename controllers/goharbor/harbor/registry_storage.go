@@ -0,0 +1,127 @@
+package harbor
+
+import (
+	"context"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/goharbor/harbor-operator/pkg/registry/storage"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigRegistryStorageProbeKey toggles the live reachability probe on top of the always-on
+	// static field validation, since it costs a network round-trip per reconciliation.
+	ConfigRegistryStorageProbeKey = "registry-storage-probe-enabled"
+
+	// StorageAvailableConditionType is recorded on Harbor.status.conditions so a misconfigured
+	// storage driver is visible on `kubectl describe` instead of only in the registry Pod's
+	// crashloop.
+	StorageAvailableConditionType = "StorageAvailable"
+	StorageUnavailableReason      = "StorageUnavailable"
+	StorageValidatedReason        = "StorageValidated"
+)
+
+// ValidateRegistryStorage statically validates harbor.Spec.Persistence.ImageChartStorage and,
+// when ConfigRegistryStorageProbeKey is enabled, probes the backend with its referenced
+// credentials. It runs from AddRegistry before the child Registry object is written, so a bad
+// bucket, region or credentials Secret is caught before the registry Pod crashloops on it.
+func (r *Reconciler) ValidateRegistryStorage(ctx context.Context, harbor *goharborv1alpha2.Harbor) error {
+	driver, err := storage.NewDriver(&harbor.Spec.Persistence.ImageChartStorage)
+	if err != nil {
+		return r.setStorageUnavailable(ctx, harbor, err)
+	}
+
+	if err := driver.ValidateSpec(); err != nil {
+		return r.setStorageUnavailable(ctx, harbor, errors.Wrapf(err, "%s", driver.Name()))
+	}
+
+	probeEnabled, err := r.getConfigBool(ConfigRegistryStorageProbeKey, false)
+	if err != nil {
+		return errors.Wrap(err, "cannot get storage probe flag")
+	}
+
+	if probeEnabled {
+		credentials, err := r.getDriverCredentials(ctx, harbor, driver)
+		if err != nil {
+			return r.setStorageUnavailable(ctx, harbor, errors.Wrapf(err, "%s credentials", driver.Name()))
+		}
+
+		if err := driver.Probe(ctx, credentials); err != nil {
+			return r.setStorageUnavailable(ctx, harbor, errors.Wrapf(err, "%s probe", driver.Name()))
+		}
+	}
+
+	changed := apimeta.SetStatusCondition(&harbor.Status.Conditions, metav1.Condition{
+		Type:    StorageAvailableConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  StorageValidatedReason,
+		Message: fmt.Sprintf("%s storage configuration is valid", driver.Name()),
+	})
+
+	// Every successful reconcile ends up here: only write when the condition actually moved, or
+	// this churns Harbor's status (and invites conflicting updates with whatever else reconciles
+	// it) on every single pass instead of just the one where storage became valid.
+	if !changed {
+		return nil
+	}
+
+	if err := r.Client.Status().Update(ctx, harbor); err != nil {
+		return errors.Wrap(err, "cannot update storage status")
+	}
+
+	return nil
+}
+
+// setStorageUnavailable records the StorageAvailable=False condition and persists it immediately,
+// rather than leaving it to whatever happens after this function returns its wrapped error: the
+// reconcile is about to abort on that error, and a deferred status flush elsewhere in the
+// reconcile loop is not something this function can rely on.
+func (r *Reconciler) setStorageUnavailable(ctx context.Context, harbor *goharborv1alpha2.Harbor, cause error) error {
+	changed := apimeta.SetStatusCondition(&harbor.Status.Conditions, metav1.Condition{
+		Type:    StorageAvailableConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  StorageUnavailableReason,
+		Message: cause.Error(),
+	})
+
+	if changed {
+		if err := r.Client.Status().Update(ctx, harbor); err != nil {
+			return errors.Wrap(err, "cannot update storage status")
+		}
+	}
+
+	return errors.Wrap(cause, "storage validation failed")
+}
+
+// getDriverCredentials fetches the Secret named by driver.SecretRef() and checks it carries every
+// key the driver's Probe needs. It returns nil credentials for drivers (such as filesystem) that
+// need no Secret at all.
+func (r *Reconciler) getDriverCredentials(ctx context.Context, harbor *goharborv1alpha2.Harbor, driver storage.Driver) (storage.Credentials, error) {
+	secretRef := driver.SecretRef()
+	if secretRef == "" {
+		return nil, nil
+	}
+
+	secret, err := r.getExistingSecret(ctx, harbor.GetNamespace(), secretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials secret")
+	}
+
+	if secret == nil {
+		return nil, errors.Errorf("credentials secret %s not found", secretRef)
+	}
+
+	credentials := storage.Credentials(secret.Data)
+
+	for _, key := range driver.RequiredCredentialKeys() {
+		if _, ok := credentials[key]; !ok {
+			return nil, errors.Errorf("credentials secret %s is missing key %s", secretRef, key)
+		}
+	}
+
+	return credentials, nil
+}
@@ -0,0 +1,119 @@
+package harbor
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/goharbor/harbor-operator/pkg/graph"
+	"github.com/pkg/errors"
+)
+
+type RemoteRegistry graph.Resource
+
+// AddRemoteRegistry reconciles the RemoteRegistry CRs declared in harbor.Spec.Replication.Registries.
+// Harbor's core must be reachable before these are pushed through its REST API, so every resource
+// depends on core.
+func (r *Reconciler) AddRemoteRegistry(ctx context.Context, harbor *goharborv1alpha2.Harbor, core Core) ([]RemoteRegistry, error) {
+	remoteRegistries, err := r.GetRemoteRegistries(ctx, harbor)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get remote registries")
+	}
+
+	results := make([]RemoteRegistry, 0, len(remoteRegistries))
+
+	for _, remoteRegistry := range remoteRegistries {
+		remoteRegistryRes, err := r.AddBasicResource(ctx, remoteRegistry, core)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot add remote registry %s", remoteRegistry.GetName())
+		}
+
+		results = append(results, RemoteRegistry(remoteRegistryRes))
+	}
+
+	return results, nil
+}
+
+func (r *Reconciler) GetRemoteRegistries(ctx context.Context, harbor *goharborv1alpha2.Harbor) ([]*goharborv1alpha2.RemoteRegistry, error) {
+	namespace := harbor.GetNamespace()
+
+	remoteRegistries := make([]*goharborv1alpha2.RemoteRegistry, 0, len(harbor.Spec.Replication.Registries))
+
+	for _, registry := range harbor.Spec.Replication.Registries {
+		name := r.NormalizeName(ctx, harbor.GetName(), "replication-registry", registry.Name)
+
+		remoteRegistries = append(remoteRegistries, &goharborv1alpha2.RemoteRegistry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: goharborv1alpha2.RemoteRegistrySpec{
+				ProviderName: registry.ProviderName,
+				URL:          registry.URL,
+				Insecure:     registry.Insecure,
+				Credential: goharborv1alpha2.RemoteRegistryCredentialSpec{
+					// Azure ACR exposes its credential under an `access_token` field instead of
+					// the `token` field every other cloud provider uses, so the credential type
+					// is threaded through rather than assumed from the provider name.
+					Type:      registry.Credential.Type,
+					SecretRef: registry.Credential.SecretRef,
+				},
+			},
+		})
+	}
+
+	return remoteRegistries, nil
+}
+
+type ReplicationPolicy graph.Resource
+
+// AddReplicationPolicy reconciles the ReplicationPolicy CRs declared in harbor.Spec.Replication.Policies.
+// Policies reference RemoteRegistry resources by name, so they too depend on core being available.
+func (r *Reconciler) AddReplicationPolicy(ctx context.Context, harbor *goharborv1alpha2.Harbor, core Core) ([]ReplicationPolicy, error) {
+	policies, err := r.GetReplicationPolicies(ctx, harbor)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get replication policies")
+	}
+
+	results := make([]ReplicationPolicy, 0, len(policies))
+
+	for _, policy := range policies {
+		policyRes, err := r.AddBasicResource(ctx, policy, core)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot add replication policy %s", policy.GetName())
+		}
+
+		results = append(results, ReplicationPolicy(policyRes))
+	}
+
+	return results, nil
+}
+
+func (r *Reconciler) GetReplicationPolicies(ctx context.Context, harbor *goharborv1alpha2.Harbor) ([]*goharborv1alpha2.ReplicationPolicy, error) {
+	namespace := harbor.GetNamespace()
+
+	policies := make([]*goharborv1alpha2.ReplicationPolicy, 0, len(harbor.Spec.Replication.Policies))
+
+	for _, policy := range harbor.Spec.Replication.Policies {
+		name := r.NormalizeName(ctx, harbor.GetName(), "replication-policy", policy.Name)
+
+		policies = append(policies, &goharborv1alpha2.ReplicationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: goharborv1alpha2.ReplicationPolicySpec{
+				SrcRegistry:   policy.SrcRegistry,
+				DestRegistry:  policy.DestRegistry,
+				DestNamespace: policy.DestNamespace,
+				Filters:       policy.Filters,
+				Trigger:       policy.Trigger,
+				Override:      policy.Override,
+				Enabled:       policy.Enabled,
+			},
+		})
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,14 @@
+// Package graph defines the opaque dependency handle threaded through a Reconciler's Add* methods,
+// so a resource that depends on another can be built from the reconcile graph instead of a
+// reconcile ordering encoded by hand.
+package graph
+
+import "sigs.k8s.io/controller-runtime/pkg/client"
+
+// Resource is a node in the reconcile dependency graph: the handle an Add* method returns once its
+// object has been created/updated, and that other Add* calls accept as a dependency so that object
+// is reconciled first. It carries no behaviour of its own beyond identifying the underlying
+// Kubernetes object.
+type Resource interface {
+	client.Object
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+type fileSystemDriver struct {
+	spec *goharborv1alpha2.HarborStorageImageChartStorageFileSystemSpec
+}
+
+func (d *fileSystemDriver) Name() string {
+	return "filesystem"
+}
+
+func (d *fileSystemDriver) ValidateSpec() error {
+	if d.spec.RootDirectory == "" {
+		return errors.New("filesystem: rootdirectory is required")
+	}
+
+	return nil
+}
+
+func (d *fileSystemDriver) SecretRef() string {
+	return ""
+}
+
+func (d *fileSystemDriver) RequiredCredentialKeys() []string {
+	return nil
+}
+
+// Probe is a no-op: the filesystem driver writes to a volume mounted into the registry Pod, which
+// the operator has no access to from the reconcile loop.
+func (d *fileSystemDriver) Probe(ctx context.Context, credentials Credentials) error {
+	return nil
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GcsCredentialKeyfile is the service account JSON key, in the same shape the distribution GCS
+	// driver expects under REGISTRY_STORAGE_GCS_KEYFILE.
+	GcsCredentialKeyfile = "gcs-key.json"
+)
+
+type gcsDriver struct {
+	spec *goharborv1alpha2.HarborStorageImageChartStorageGcsSpec
+}
+
+func (d *gcsDriver) Name() string {
+	return "gcs"
+}
+
+func (d *gcsDriver) ValidateSpec() error {
+	if d.spec.Bucket == "" {
+		return errors.New("gcs: bucket is required")
+	}
+
+	if d.spec.SecretRef == "" {
+		return errors.New("gcs: secretRef is required")
+	}
+
+	return nil
+}
+
+func (d *gcsDriver) SecretRef() string {
+	return d.spec.SecretRef
+}
+
+func (d *gcsDriver) RequiredCredentialKeys() []string {
+	return []string{GcsCredentialKeyfile}
+}
+
+// Probe checks the credential keys and dials the fixed GCS storage API endpoint: unlike S3 and
+// Swift there is no account-specific host to reach, so the bucket itself is not checked.
+func (d *gcsDriver) Probe(ctx context.Context, credentials Credentials) error {
+	if err := requireCredentialKeys(credentials, GcsCredentialKeyfile); err != nil {
+		return err
+	}
+
+	return dialEndpoint(ctx, "storage.googleapis.com:443")
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// dialTimeout bounds how long a Probe waits for the backend endpoint to accept a connection, so a
+// network partition fails reconciliation quickly instead of hanging it.
+const dialTimeout = 5 * time.Second
+
+// dialEndpoint confirms addr (host:port) accepts TCP connections, the cheapest check available
+// without pulling in each cloud provider's SDK.
+func dialEndpoint(ctx context.Context, addr string) error {
+	dialer := net.Dialer{Timeout: dialTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot reach %s", addr)
+	}
+
+	return conn.Close()
+}
+
+func requireCredentialKeys(credentials Credentials, keys ...string) error {
+	for _, key := range keys {
+		if _, ok := credentials[key]; !ok {
+			return errors.Errorf("credentials secret is missing key %s", key)
+		}
+	}
+
+	return nil
+}
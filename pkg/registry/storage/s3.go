@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"net"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+const (
+	S3CredentialAccessKey = "access-key"
+	S3CredentialSecretKey = "secret-key"
+)
+
+type s3Driver struct {
+	spec *goharborv1alpha2.HarborStorageImageChartStorageS3Spec
+}
+
+func (d *s3Driver) Name() string {
+	return "s3"
+}
+
+func (d *s3Driver) ValidateSpec() error {
+	if d.spec.Bucket == "" {
+		return errors.New("s3: bucket is required")
+	}
+
+	if d.spec.Region == "" && d.spec.RegionEndpoint == "" {
+		return errors.New("s3: one of region or regionendpoint is required")
+	}
+
+	if d.spec.SecretRef == "" {
+		return errors.New("s3: secretRef is required")
+	}
+
+	return nil
+}
+
+func (d *s3Driver) SecretRef() string {
+	return d.spec.SecretRef
+}
+
+func (d *s3Driver) RequiredCredentialKeys() []string {
+	return []string{S3CredentialAccessKey, S3CredentialSecretKey}
+}
+
+// Probe dials the configured endpoint - or, lacking one, the regional S3 endpoint - rather than
+// pulling in the AWS SDK just to HEAD a bucket.
+func (d *s3Driver) Probe(ctx context.Context, credentials Credentials) error {
+	if err := requireCredentialKeys(credentials, S3CredentialAccessKey, S3CredentialSecretKey); err != nil {
+		return err
+	}
+
+	endpoint := d.spec.RegionEndpoint
+	if endpoint == "" {
+		endpoint = "s3." + d.spec.Region + ".amazonaws.com"
+	}
+
+	host := endpoint
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		host = net.JoinHostPort(endpoint, "443")
+	}
+
+	return dialEndpoint(ctx, host)
+}
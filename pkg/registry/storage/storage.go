@@ -0,0 +1,72 @@
+// Package storage validates the storage driver configured for a Harbor's registry before the
+// operator writes the child Registry object, so a misconfigured bucket or endpoint is reported on
+// Harbor.status.conditions instead of only surfacing once the registry Pod crashloops.
+package storage
+
+import (
+	"context"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+// Credentials is the resolved data of the Secret referenced by a driver's credentials SecretRef,
+// keyed the same way as the Secret's Data.
+type Credentials map[string][]byte
+
+// Driver validates a single image/chart storage backend: its static configuration first, then -
+// when asked - whether the backend is actually reachable with the resolved credentials.
+type Driver interface {
+	// Name identifies the driver in error messages and status conditions.
+	Name() string
+
+	// ValidateSpec checks the fields this driver requires are set, without making any network call.
+	ValidateSpec() error
+
+	// SecretRef is the name of the Secret holding this driver's credentials, or "" if the driver
+	// needs none.
+	SecretRef() string
+
+	// RequiredCredentialKeys lists the keys Probe expects to find in the Secret named by SecretRef.
+	RequiredCredentialKeys() []string
+
+	// Probe checks the resolved credentials carry the required keys and dials the backend's
+	// endpoint (the filesystem driver's Probe does nothing); it does not HEAD the bucket or list a
+	// prefix.
+	Probe(ctx context.Context, credentials Credentials) error
+}
+
+// NewDriver returns the Driver matching the backend configured in spec, or an error if none - or
+// more than one - is set.
+func NewDriver(spec *goharborv1alpha2.HarborStorageImageChartStorageSpec) (Driver, error) {
+	var drivers []Driver
+
+	if spec.FileSystem != nil {
+		drivers = append(drivers, &fileSystemDriver{spec.FileSystem})
+	}
+
+	if spec.S3 != nil {
+		drivers = append(drivers, &s3Driver{spec.S3})
+	}
+
+	if spec.Swift != nil {
+		drivers = append(drivers, &swiftDriver{spec.Swift})
+	}
+
+	if spec.Gcs != nil {
+		drivers = append(drivers, &gcsDriver{spec.Gcs})
+	}
+
+	if spec.Azure != nil {
+		drivers = append(drivers, &azureDriver{spec.Azure})
+	}
+
+	switch len(drivers) {
+	case 0:
+		return nil, errors.New("no storage driver configured")
+	case 1:
+		return drivers[0], nil
+	default:
+		return nil, errors.Errorf("%d storage drivers configured, exactly one is supported", len(drivers))
+	}
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	goharborv1alpha2 "github.com/goharbor/harbor-operator/apis/goharbor.io/v1alpha2"
+	"github.com/pkg/errors"
+)
+
+const (
+	SwiftCredentialUsername = "username"
+	SwiftCredentialPassword = "password"
+)
+
+type swiftDriver struct {
+	spec *goharborv1alpha2.HarborStorageImageChartStorageSwiftSpec
+}
+
+func (d *swiftDriver) Name() string {
+	return "swift"
+}
+
+func (d *swiftDriver) ValidateSpec() error {
+	if d.spec.AuthURL == "" {
+		return errors.New("swift: authurl is required")
+	}
+
+	if d.spec.Container == "" {
+		return errors.New("swift: container is required")
+	}
+
+	if d.spec.SecretRef == "" {
+		return errors.New("swift: secretRef is required")
+	}
+
+	return nil
+}
+
+func (d *swiftDriver) SecretRef() string {
+	return d.spec.SecretRef
+}
+
+func (d *swiftDriver) RequiredCredentialKeys() []string {
+	return []string{SwiftCredentialUsername, SwiftCredentialPassword}
+}
+
+func (d *swiftDriver) Probe(ctx context.Context, credentials Credentials) error {
+	if err := requireCredentialKeys(credentials, SwiftCredentialUsername, SwiftCredentialPassword); err != nil {
+		return err
+	}
+
+	authURL, err := url.Parse(d.spec.AuthURL)
+	if err != nil {
+		return errors.Wrap(err, "swift: cannot parse authurl")
+	}
+
+	host := authURL.Host
+	if authURL.Port() == "" {
+		port := "80"
+		if authURL.Scheme == "https" {
+			port = "443"
+		}
+
+		host = net.JoinHostPort(authURL.Hostname(), port)
+	}
+
+	return dialEndpoint(ctx, host)
+}